@@ -0,0 +1,290 @@
+// Package bayes implements a multinomial naive Bayes text classifier with a
+// pluggable tokenizer and gob-based model persistence.
+package bayes
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+/* Example:
+{
+	word: "restaurant",
+	counter: {
+		positive: 2,
+		negative: 0,
+	}
+}
+*/
+type wordFrequency struct {
+	word    string
+	counter map[string]int
+}
+
+/*	Example:
+{
+	dataset: {
+		positive: [
+			"The restaurant is excellent",
+			"Second sentence"
+		],
+		negative: [
+			"Some negative
+		]
+	},
+	words: {
+		restaurant: {
+			word: "restaurant",
+			counter: {
+				positive: 2,
+				negative: 0,
+			}
+		}
+	}
+}
+*/
+
+// Classifier is a multi-class naive Bayes text classifier. Use NewClassifier
+// to construct one; the zero value is not usable. A Classifier is safe for
+// concurrent use: Learn/LearnAsync take an exclusive lock, Score/Classify
+// take a shared one, so classification stays safe while the model is being
+// updated live.
+type Classifier struct {
+	mu      sync.RWMutex
+	classes []string
+	dataset map[string][]string
+	words   map[string]wordFrequency
+
+	// Generation counts how many times the model has been updated via
+	// Learn or LearnAsync. Callers can poll it to detect that a model
+	// (e.g. one shared with a serving goroutine) has changed.
+	Generation int64
+
+	stats actionCounters // see online.go
+
+	// cacheMu guards wordCountCache/distinctWordCountCache/cacheStale
+	// separately from mu, since they're refreshed from Score while mu is
+	// only read-locked.
+	cacheMu sync.Mutex
+	// wordCountCache and distinctWordCountCache memoize wordCount/
+	// totalDistinctWordCount, which would otherwise be O(words) and get
+	// re-walked for every token of every classification. They go stale on
+	// addWord and are lazily recomputed on next read.
+	wordCountCache         map[string]int
+	distinctWordCountCache int
+	cacheStale             bool
+
+	// Tokenizer turns a piece of text into the features used for training
+	// and classification. Defaults to DefaultTokenizer.
+	Tokenizer Tokenizer
+}
+
+// NewClassifier creates a Classifier for the given set of classes. At least
+// one class must be provided.
+func NewClassifier(classes ...string) *Classifier {
+	c := &Classifier{
+		classes:        classes,
+		dataset:        map[string][]string{},
+		words:          map[string]wordFrequency{},
+		wordCountCache: map[string]int{},
+		Tokenizer:      DefaultTokenizer,
+	}
+	for _, class := range classes {
+		c.dataset[class] = nil
+	}
+	return c
+}
+
+// Learn trains the classifier with a single labeled piece of text. It
+// returns an error if class is not one the classifier was constructed with.
+func (c *Classifier) Learn(text, class string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.dataset[class]; !ok {
+		atomic.AddUint64(&c.stats.blocked, 1)
+		return fmt.Errorf("bayes: unknown class %q", class)
+	}
+
+	c.dataset[class] = append(c.dataset[class], text)
+	for _, w := range c.Tokenizer(text) {
+		c.addWord(w, class)
+	}
+	atomic.AddInt64(&c.Generation, 1)
+	atomic.AddUint64(&c.stats.learned, 1)
+	return nil
+}
+
+// Score returns, for every class the classifier knows about, the log of the
+// (unnormalized) naive Bayes probability that text belongs to that class.
+// Working in log-space keeps the score from underflowing to 0 on long
+// inputs, where multiplying together many small per-word probabilities
+// would otherwise flush the product to zero; use ClassifyProbabilities to
+// turn these into normalized probabilities.
+func (c *Classifier) Score(text string) map[string]float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	words := c.Tokenizer(text)
+	scores := make(map[string]float64, len(c.classes))
+	for _, class := range c.classes {
+		scores[class] = c.logProbability(words, class)
+	}
+	return scores
+}
+
+// ClassifyProbabilities returns the probability of text belonging to each
+// class, normalized so the values sum to 1. It converts Score's log-space
+// values back to plain probabilities using the log-sum-exp trick, so the
+// conversion itself stays numerically stable even when the log scores are
+// very negative.
+func (c *Classifier) ClassifyProbabilities(text string) map[string]float64 {
+	logScores := c.Score(text)
+
+	logTotal := math.Inf(-1)
+	for _, class := range c.classes {
+		logTotal = logAdd(logTotal, logScores[class])
+	}
+
+	probs := make(map[string]float64, len(logScores))
+	for class, logScore := range logScores {
+		probs[class] = math.Exp(logScore - logTotal)
+	}
+	return probs
+}
+
+// logAdd returns log(exp(a) + exp(b)) without computing exp(a) or exp(b)
+// directly, which would underflow for very negative log-probabilities.
+func logAdd(a, b float64) float64 {
+	if a == math.Inf(-1) {
+		return b
+	}
+	if b == math.Inf(-1) {
+		return a
+	}
+	if a < b {
+		a, b = b, a
+	}
+	return a + math.Log1p(math.Exp(b-a))
+}
+
+// Classify returns the single most likely class for text.
+func (c *Classifier) Classify(text string) string {
+	probs := c.ClassifyProbabilities(text)
+	atomic.AddUint64(&c.stats.classified, 1)
+
+	var best string
+	var bestProb float64
+	for _, class := range c.classes {
+		if p := probs[class]; best == "" || p > bestProb {
+			best = class
+			bestProb = p
+		}
+	}
+	return best
+}
+
+func (c *Classifier) addWord(word, class string) {
+	wf, ok := c.words[word]
+	if !ok {
+		wf = wordFrequency{
+			word:    word,
+			counter: map[string]int{},
+		}
+		for _, class := range c.classes {
+			wf.counter[class] = 0
+		}
+	}
+	wf.counter[class]++
+	c.words[word] = wf
+	c.cacheStale = true
+}
+
+func (c *Classifier) priorProb(class string) float64 {
+	classCount := float64(len(c.dataset[class]))
+	totalCount := float64(c.totalDocumentCount())
+	return classCount / totalCount
+}
+
+func (c *Classifier) totalDocumentCount() int {
+	total := 0
+	for _, docs := range c.dataset {
+		total += len(docs)
+	}
+	return total
+}
+
+// refreshCaches recomputes wordCountCache and distinctWordCountCache in a
+// single pass over c.words. It is a no-op unless addWord has touched the
+// model since the last refresh.
+func (c *Classifier) refreshCaches() {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+
+	if !c.cacheStale {
+		return
+	}
+
+	wordCountCache := make(map[string]int, len(c.classes))
+	distinctWordCount := 0
+	for _, wf := range c.words {
+		for _, class := range c.classes {
+			n := wf.counter[class]
+			wordCountCache[class] += n
+			distinctWordCount += zeroOneTransform(n)
+		}
+	}
+
+	c.wordCountCache = wordCountCache
+	c.distinctWordCountCache = distinctWordCount
+	c.cacheStale = false
+}
+
+func (c *Classifier) wordCount(class string) int {
+	c.refreshCaches()
+	return c.wordCountCache[class]
+}
+
+func (c *Classifier) totalWordCount() int {
+	count := 0
+	for _, class := range c.classes {
+		count += c.wordCount(class)
+	}
+	return count
+}
+
+func (c *Classifier) totalDistinctWordCount() int {
+	c.refreshCaches()
+	return c.distinctWordCountCache
+}
+
+// logProbability returns log(priorProb(class)) + Σ log((count+1)/(wordCount(class)+V)),
+// the log of the (unnormalized) naive Bayes probability of words given class.
+// Accumulating in log-space avoids multiplying together many small
+// per-word fractions, which underflows to 0 for realistic documents.
+//
+// https://medium.com/@kcatstack/sentiment-analysis-naive-bayes-classifier-from-scratch-part-1-theory-4949115ba13
+func (c *Classifier) logProbability(words []string, class string) float64 {
+	v := float64(c.totalDistinctWordCount())
+	wordCount := float64(c.wordCount(class))
+
+	logProb := math.Log(c.priorProb(class))
+	for _, w := range words {
+		count := 0
+		if wf, ok := c.words[w]; ok {
+			count = wf.counter[class]
+		}
+		logProb += math.Log(float64(count+1) / (wordCount + v))
+	}
+
+	return logProb
+}
+
+func zeroOneTransform(x int) int {
+	if x == 0 {
+		return 0
+	}
+	return 1
+}
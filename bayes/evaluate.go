@@ -0,0 +1,163 @@
+package bayes
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// ClassMetrics holds precision, recall and F1 for a single class.
+type ClassMetrics struct {
+	Precision float64
+	Recall    float64
+	F1        float64
+}
+
+// Report is the result of CrossValidate: overall accuracy, a confusion
+// matrix keyed confusion[actual][predicted], and per-class metrics.
+type Report struct {
+	Accuracy  float64
+	Confusion map[string]map[string]int
+	PerClass  map[string]ClassMetrics
+}
+
+// CrossValidate performs k-fold cross-validation over data: it is split
+// into k folds, and for each fold a fresh classifier (same classes and
+// Tokenizer as c, but untrained) is trained on the remaining k-1 folds and
+// evaluated against the held-out one. The per-fold results are pooled into
+// a single accuracy, confusion matrix and set of per-class
+// precision/recall/F1, so model changes can be benchmarked reproducibly
+// instead of classifying sentences typed into stdin by hand.
+func (c *Classifier) CrossValidate(data map[string]string, k int) Report {
+	folds := splitFolds(data, k)
+	confusion := newConfusion(c.classes)
+
+	for i, test := range folds {
+		fold := NewClassifier(c.classes...)
+		fold.Tokenizer = c.Tokenizer
+
+		for j, train := range folds {
+			if j == i {
+				continue
+			}
+			for text, class := range train {
+				_ = fold.Learn(text, class)
+			}
+		}
+
+		for text, actual := range test {
+			predicted := fold.Classify(text)
+			confusion[actual][predicted]++
+		}
+	}
+
+	return newReport(confusion, c.classes)
+}
+
+// TrainTestSplit randomly splits data into a training set and a test set,
+// with ratio (in (0, 1)) of the examples going to the training set. seed
+// makes the split reproducible, e.g. to benchmark model changes against the
+// same 85/15 split.
+func TrainTestSplit(data map[string]string, ratio float64, seed int64) (train, test map[string]string) {
+	texts := make([]string, 0, len(data))
+	for text := range data {
+		texts = append(texts, text)
+	}
+	sort.Strings(texts)
+
+	rnd := rand.New(rand.NewSource(seed))
+	rnd.Shuffle(len(texts), func(i, j int) {
+		texts[i], texts[j] = texts[j], texts[i]
+	})
+
+	split := int(float64(len(texts)) * ratio)
+	train = make(map[string]string, split)
+	test = make(map[string]string, len(texts)-split)
+	for i, text := range texts {
+		if i < split {
+			train[text] = data[text]
+		} else {
+			test[text] = data[text]
+		}
+	}
+	return train, test
+}
+
+// splitFolds partitions data into k folds by round-robin over the
+// lexicographically sorted texts, so the split is deterministic regardless
+// of Go's randomized map iteration order.
+func splitFolds(data map[string]string, k int) []map[string]string {
+	texts := make([]string, 0, len(data))
+	for text := range data {
+		texts = append(texts, text)
+	}
+	sort.Strings(texts)
+
+	folds := make([]map[string]string, k)
+	for i := range folds {
+		folds[i] = map[string]string{}
+	}
+	for i, text := range texts {
+		folds[i%k][text] = data[text]
+	}
+	return folds
+}
+
+func newConfusion(classes []string) map[string]map[string]int {
+	confusion := make(map[string]map[string]int, len(classes))
+	for _, actual := range classes {
+		confusion[actual] = make(map[string]int, len(classes))
+		for _, predicted := range classes {
+			confusion[actual][predicted] = 0
+		}
+	}
+	return confusion
+}
+
+func newReport(confusion map[string]map[string]int, classes []string) Report {
+	var correct, total int
+	for _, actual := range classes {
+		for predicted, count := range confusion[actual] {
+			total += count
+			if predicted == actual {
+				correct += count
+			}
+		}
+	}
+
+	perClass := make(map[string]ClassMetrics, len(classes))
+	for _, class := range classes {
+		var tp, fp, fn int
+		for _, actual := range classes {
+			for predicted, count := range confusion[actual] {
+				switch {
+				case actual == class && predicted == class:
+					tp += count
+				case actual != class && predicted == class:
+					fp += count
+				case actual == class && predicted != class:
+					fn += count
+				}
+			}
+		}
+
+		var precision, recall, f1 float64
+		if tp+fp > 0 {
+			precision = float64(tp) / float64(tp+fp)
+		}
+		if tp+fn > 0 {
+			recall = float64(tp) / float64(tp+fn)
+		}
+		if precision+recall > 0 {
+			f1 = 2 * precision * recall / (precision + recall)
+		}
+
+		perClass[class] = ClassMetrics{Precision: precision, Recall: recall, F1: f1}
+	}
+
+	var accuracy float64
+	if total > 0 {
+		accuracy = float64(correct) / float64(total)
+	}
+
+	return Report{Accuracy: accuracy, Confusion: confusion, PerClass: perClass}
+}
@@ -0,0 +1,42 @@
+package bayes
+
+import "strings"
+
+// NGramTokenizer returns a Transformer that joins every run of n consecutive
+// tokens into a single feature using joiner, e.g. NGramTokenizer(2, "_")
+// turns ["not", "good"] into ["not_good"]. Put it in a Pipeline after the
+// stages that produce unigrams (Lowercase, StripPunct, ...) but before
+// Stopwords, since removing "not" as a unigram would otherwise destroy the
+// negation context a bigram is meant to recover. n<=1 is a no-op.
+func NGramTokenizer(n int, joiner string) Transformer {
+	return func(tokens []string) []string {
+		if n <= 1 || len(tokens) < n {
+			return tokens
+		}
+
+		out := make([]string, 0, len(tokens)-n+1)
+		for i := 0; i+n <= len(tokens); i++ {
+			out = append(out, strings.Join(tokens[i:i+n], joiner))
+		}
+		return out
+	}
+}
+
+// CompositeTokenizer merges the tokens produced by each of the given
+// tokenizers into a single feature stream. It's the way to combine n-gram
+// ranges (e.g. unigrams and bigrams together), since each range needs its
+// own Pipeline:
+//
+//	classifier.Tokenizer = CompositeTokenizer(
+//		Pipeline{Lowercase, StripPunct, Stopwords("en")}.Tokenize,
+//		Pipeline{Lowercase, StripPunct, NGramTokenizer(2, "_")}.Tokenize,
+//	)
+func CompositeTokenizer(tokenizers ...Tokenizer) Tokenizer {
+	return func(text string) []string {
+		var tokens []string
+		for _, tokenize := range tokenizers {
+			tokens = append(tokens, tokenize(text)...)
+		}
+		return tokens
+	}
+}
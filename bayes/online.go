@@ -0,0 +1,109 @@
+package bayes
+
+import "sync/atomic"
+
+// actionCounters holds the atomic counters backing Stats.
+type actionCounters struct {
+	learned    uint64
+	classified uint64
+	blocked    uint64
+}
+
+// Stats is a point-in-time snapshot of the actions a Classifier has
+// performed, suitable for exposing on a metrics endpoint.
+type Stats struct {
+	Learned    uint64
+	Classified uint64
+	Blocked    uint64
+}
+
+// Stats returns the current action counters.
+func (c *Classifier) Stats() Stats {
+	return Stats{
+		Learned:    atomic.LoadUint64(&c.stats.learned),
+		Classified: atomic.LoadUint64(&c.stats.classified),
+		Blocked:    atomic.LoadUint64(&c.stats.blocked),
+	}
+}
+
+// LearnAsync starts one goroutine per entry of feeds, each reading text from
+// its channel and learning it under the associated class, so a running
+// service can keep updating the model from live feedback (e.g. GOOD/BAD/MEH
+// signals) without retraining from a file. Each goroutine runs until its
+// channel is closed.
+func (c *Classifier) LearnAsync(feeds map[string]<-chan string) {
+	for class, feed := range feeds {
+		class, feed := class, feed
+		go func() {
+			for text := range feed {
+				c.learnOnline(text, class)
+			}
+		}()
+	}
+}
+
+// learnOnline is the LearnAsync counterpart to Learn: it applies the MEH
+// demotion rule (see addWordMEH) instead of blindly incrementing counters,
+// since live feedback can label the same token under contradictory classes.
+func (c *Classifier) learnOnline(text, class string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.dataset[class]; !ok {
+		atomic.AddUint64(&c.stats.blocked, 1)
+		return
+	}
+
+	c.dataset[class] = append(c.dataset[class], text)
+	for _, w := range c.Tokenizer(text) {
+		c.addWordMEH(w, class)
+	}
+	atomic.AddInt64(&c.Generation, 1)
+	atomic.AddUint64(&c.stats.learned, 1)
+}
+
+// addWordMEH is like addWord, except that when word already has a dominant
+// class and the new label contradicts it, the signal is treated as
+// ambiguous ("MEH") rather than reinforcing two opposing classes: the
+// previously dominant class's counter is demoted by one instead of
+// incrementing the new class's counter.
+func (c *Classifier) addWordMEH(word, class string) {
+	wf, ok := c.words[word]
+	if !ok {
+		wf = wordFrequency{
+			word:    word,
+			counter: map[string]int{},
+		}
+		for _, cl := range c.classes {
+			wf.counter[cl] = 0
+		}
+		wf.counter[class]++
+		c.words[word] = wf
+		c.cacheStale = true
+		return
+	}
+
+	if dominant := dominantClass(wf.counter, c.classes); dominant != "" && dominant != class {
+		if wf.counter[dominant] > 0 {
+			wf.counter[dominant]--
+		}
+	} else {
+		wf.counter[class]++
+	}
+	c.words[word] = wf
+	c.cacheStale = true
+}
+
+// dominantClass returns the class with the highest counter for a word, or
+// "" if every class is tied at zero.
+func dominantClass(counter map[string]int, classes []string) string {
+	best := ""
+	bestCount := 0
+	for _, class := range classes {
+		if n := counter[class]; n > bestCount {
+			best = class
+			bestCount = n
+		}
+	}
+	return best
+}
@@ -0,0 +1,74 @@
+package bayes
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/kljensen/snowball"
+)
+
+// Transformer maps a slice of tokens to another slice of tokens: lowercasing,
+// punctuation stripping, stopword removal and stemming are all Transformers.
+type Transformer func([]string) []string
+
+// Pipeline is a composable, ordered alternative to a single Tokenizer
+// function. Build one from the stages below and use its Tokenize method as
+// a Classifier's Tokenizer, e.g.:
+//
+//	classifier.Tokenizer = Pipeline{Lowercase, StripPunct, Stopwords("en"), Stem("english")}.Tokenize
+type Pipeline []Transformer
+
+// Tokenize splits text on whitespace and runs the resulting tokens through
+// every stage of the pipeline, in order.
+func (p Pipeline) Tokenize(text string) []string {
+	tokens := strings.Fields(text)
+	for _, transform := range p {
+		tokens = transform(tokens)
+	}
+	return tokens
+}
+
+// Lowercase lowercases every token.
+func Lowercase(tokens []string) []string {
+	out := make([]string, len(tokens))
+	for i, t := range tokens {
+		out[i] = strings.ToLower(t)
+	}
+	return out
+}
+
+// StripPunct trims leading and trailing punctuation/symbols from each token
+// and drops tokens that end up empty. It strips rune-by-rune using
+// unicode.IsLetter/unicode.IsDigit rather than an ASCII regex, so it doesn't
+// mangle non-English text the way `[^a-zA-Z0-9]` would.
+func StripPunct(tokens []string) []string {
+	out := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		stripped := strings.TrimFunc(t, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if stripped != "" {
+			out = append(out, stripped)
+		}
+	}
+	return out
+}
+
+// Stem returns a Transformer that reduces each token to its Snowball stem
+// (e.g. "restaurants" -> "restaur"), so morphological variants collapse to
+// the same feature. language follows github.com/kljensen/snowball, e.g.
+// "english". Tokens the stemmer can't handle are passed through unchanged.
+func Stem(language string) Transformer {
+	return func(tokens []string) []string {
+		out := make([]string, len(tokens))
+		for i, t := range tokens {
+			stemmed, err := snowball.Stem(t, language, false)
+			if err != nil {
+				out[i] = t
+				continue
+			}
+			out[i] = stemmed
+		}
+		return out
+	}
+}
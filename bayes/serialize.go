@@ -0,0 +1,64 @@
+package bayes
+
+import (
+	"encoding/gob"
+	"io"
+)
+
+// model is the gob-serializable representation of a Classifier. Classifier
+// itself keeps its fields unexported, so Serialize/Deserialize convert to
+// and from this shape instead of exporting internal state directly.
+type model struct {
+	Classes []string
+	Dataset map[string][]string
+	Words   map[string]wordFrequencyModel
+}
+
+type wordFrequencyModel struct {
+	Word    string
+	Counter map[string]int
+}
+
+// Serialize writes the trained model (classes, dataset and word counters) to
+// w using encoding/gob, so it can be reloaded later with Deserialize without
+// retraining. The Tokenizer is not part of the saved state and must be set
+// again by the caller after Deserialize if a non-default one was used.
+func (c *Classifier) Serialize(w io.Writer) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	m := model{
+		Classes: c.classes,
+		Dataset: c.dataset,
+		Words:   make(map[string]wordFrequencyModel, len(c.words)),
+	}
+	for word, wf := range c.words {
+		m.Words[word] = wordFrequencyModel{Word: wf.word, Counter: wf.counter}
+	}
+
+	return gob.NewEncoder(w).Encode(m)
+}
+
+// Deserialize replaces the classifier's trained state with the model read
+// from r. The Tokenizer field is left untouched, defaulting to
+// DefaultTokenizer if this Classifier was constructed with NewClassifier.
+func (c *Classifier) Deserialize(r io.Reader) error {
+	var m model
+	if err := gob.NewDecoder(r).Decode(&m); err != nil {
+		return err
+	}
+
+	words := make(map[string]wordFrequency, len(m.Words))
+	for word, wf := range m.Words {
+		words[word] = wordFrequency{word: wf.Word, counter: wf.Counter}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.classes = m.Classes
+	c.dataset = m.Dataset
+	c.words = words
+	c.cacheStale = true
+	return nil
+}
@@ -1,12 +1,33 @@
-package main
+package bayes
 
-import (
-	"regexp"
-	"strings"
-)
+// stopWordsByLanguage holds stopword sets keyed by language code. Only
+// English is built in today; Stopwords passes tokens through unchanged for
+// any other language.
+var stopWordsByLanguage = map[string]map[string]struct{}{
+	"en": enStopWords,
+}
 
-// stopWords are words which have very little meaning
-var stopWords = map[string]struct{}{
+// Stopwords returns a Transformer that drops stopwords for the given
+// language code (e.g. "en"). Unknown language codes are a no-op, since a
+// language without a known list shouldn't silently lose every token.
+func Stopwords(language string) Transformer {
+	words, ok := stopWordsByLanguage[language]
+	return func(tokens []string) []string {
+		if !ok {
+			return tokens
+		}
+		out := make([]string, 0, len(tokens))
+		for _, t := range tokens {
+			if _, stop := words[t]; !stop {
+				out = append(out, t)
+			}
+		}
+		return out
+	}
+}
+
+// enStopWords are English words which have very little meaning on their own.
+var enStopWords = map[string]struct{}{
 	"i": {}, "me": {}, "my": {}, "myself": {}, "we": {}, "our": {}, "ours": {},
 	"ourselves": {}, "you": {}, "your": {}, "yours": {}, "yourself": {}, "yourselves": {},
 	"he": {}, "him": {}, "his": {}, "himself": {}, "she": {}, "her": {}, "hers": {},
@@ -28,25 +49,3 @@ var stopWords = map[string]struct{}{
 	"now": {}, "aren't": {}, "couldn't": {}, "didn't": {}, "doesn't": {}, "hasn't": {}, "haven't": {},
 	"isn't": {}, "shouldn't": {}, "wasn't": {}, "weren't": {}, "won't": {}, "wouldn't": {},
 }
-
-func isStopWord(w string) bool {
-	_, ok := stopWords[w]
-	return ok
-}
-
-func clenup(sentence string) string {
-	re := regexp.MustCompile("[^a-zA-Z 0-9]+")
-	return re.ReplaceAllLiteralString(strings.ToLower(sentence), "")
-}
-
-func tokenize(sentence string) []string {
-	s := clenup(sentence)
-	words := strings.Fields(s)
-	var tokens []string
-	for _, w := range words {
-		if !isStopWord(w) {
-			tokens = append(tokens, w)
-		}
-	}
-	return tokens
-}
@@ -0,0 +1,12 @@
+package bayes
+
+// Tokenizer splits a piece of text into the features used for training and
+// classification. Callers can plug in their own (stemming, n-grams,
+// language-specific pipelines, ...) via Classifier.Tokenizer; DefaultTokenizer
+// is used when none is set.
+type Tokenizer func(string) []string
+
+// DefaultTokenizer lowercases the input, strips punctuation in a
+// Unicode-aware way, and drops English stopwords. It does not stem or
+// split into n-grams; build a custom Pipeline for that.
+var DefaultTokenizer = Pipeline{Lowercase, StripPunct, Stopwords("en")}.Tokenize